@@ -38,22 +38,25 @@ func main() {
 
 	type meta = map[string]any
 
-	// Add documents to the vector store. So far chromem-go only supports string
-	// values metadata maps.
+	// Add documents to the vector store. population and area are genuinely
+	// numeric; the chromem store transparently encodes/decodes them instead
+	// of having to be stringified here. Values written with a decimal point
+	// round-trip as float64, plain ints (all areas, and Paris's population)
+	// as int64.
 	docs := []schema.Document{
-		{PageContent: "Tokyo", Metadata: meta{"population": "9.7", "area": "622"}},
-		{PageContent: "Kyoto", Metadata: meta{"population": "1.46", "area": "828"}},
-		{PageContent: "Hiroshima", Metadata: meta{"population": "1.2", "area": "905"}},
-		{PageContent: "Kazuno", Metadata: meta{"population": "0.04", "area": "707"}},
-		{PageContent: "Nagoya", Metadata: meta{"population": "2.3", "area": "326"}},
-		{PageContent: "Toyota", Metadata: meta{"population": "0.42", "area": "918"}},
-		{PageContent: "Fukuoka", Metadata: meta{"population": "1.59", "area": "341"}},
-		{PageContent: "Paris", Metadata: meta{"population": "11", "area": "105"}},
-		{PageContent: "London", Metadata: meta{"population": "9.5", "area": "1572"}},
-		{PageContent: "Santiago", Metadata: meta{"population": "6.9", "area": "641"}},
-		{PageContent: "Buenos Aires", Metadata: meta{"population": "15.5", "area": "203"}},
-		{PageContent: "Rio de Janeiro", Metadata: meta{"population": "13.7", "area": "1200"}},
-		{PageContent: "Sao Paulo", Metadata: meta{"population": "22.6", "area": "1523"}},
+		{PageContent: "Tokyo", Metadata: meta{"population": 9.7, "area": 622}},
+		{PageContent: "Kyoto", Metadata: meta{"population": 1.46, "area": 828}},
+		{PageContent: "Hiroshima", Metadata: meta{"population": 1.2, "area": 905}},
+		{PageContent: "Kazuno", Metadata: meta{"population": 0.04, "area": 707}},
+		{PageContent: "Nagoya", Metadata: meta{"population": 2.3, "area": 326}},
+		{PageContent: "Toyota", Metadata: meta{"population": 0.42, "area": 918}},
+		{PageContent: "Fukuoka", Metadata: meta{"population": 1.59, "area": 341}},
+		{PageContent: "Paris", Metadata: meta{"population": 11, "area": 105}},
+		{PageContent: "London", Metadata: meta{"population": 9.5, "area": 1572}},
+		{PageContent: "Santiago", Metadata: meta{"population": 6.9, "area": 641}},
+		{PageContent: "Buenos Aires", Metadata: meta{"population": 15.5, "area": 203}},
+		{PageContent: "Rio de Janeiro", Metadata: meta{"population": 13.7, "area": 1200}},
+		{PageContent: "Sao Paulo", Metadata: meta{"population": 22.6, "area": 1523}},
 	}
 	_, err = store.AddDocuments(context.Background(), docs)
 	if err != nil {
@@ -89,7 +92,7 @@ func main() {
 			query:        "Which city is located in South America?",
 			numDocuments: len(docs), // The filter already limits the result
 			options: []vectorstores.Option{
-				vectorstores.WithFilters(map[string]string{"area": "1523"}), // Sao Paolo
+				vectorstores.WithFilters(map[string]any{"area": 1523}), // Sao Paolo
 			},
 		},
 	}
@@ -0,0 +1,165 @@
+package chromem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	chromemgo "github.com/philippgille/chromem-go"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// BatchEmbedder is an optional interface an [embeddings.Embedder] can
+// implement to hint that chromem-go should take over embedding and inserting
+// a batch itself, via its own [chromemgo.Collection.AddDocuments], which
+// embeds and inserts documents concurrently. Without the hint, addBatch falls
+// back to embedding the whole batch with one [embeddings.Embedder.EmbedDocuments]
+// call and inserting the results one document at a time.
+type BatchEmbedder interface {
+	embeddings.Embedder
+
+	// SupportsChromemBatching reports whether it's safe and efficient to let
+	// chromem-go's own [chromemgo.Collection.AddDocuments] drive embedding and
+	// insertion of a batch concurrently, instead of embedding it upfront via
+	// EmbedDocuments.
+	SupportsChromemBatching() bool
+}
+
+// addDocumentsInBatches shards docs into batches of s.batchSize (the whole
+// input if unset), embeds and inserts up to s.concurrency of them in
+// parallel, and reports progress via s.progressCallback after each batch. It
+// returns the IDs in the same order as docs, and an aggregated error if any
+// batch failed - other batches still run to completion rather than being
+// aborted, since there's no useful way to half-insert a batch anyway.
+func (s *Store) addDocumentsInBatches(ctx context.Context, c *chromemgo.Collection, docs []schema.Document) ([]string, error) {
+	batchSize := s.batchSize
+	if batchSize <= 0 {
+		batchSize = len(docs)
+	}
+	concurrency := s.concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	type batch struct {
+		docs []schema.Document
+		ids  []string
+	}
+
+	var batches []batch
+	for start := 0; start < len(docs); start += batchSize {
+		end := start + batchSize
+		if end > len(docs) {
+			end = len(docs)
+		}
+
+		ids := make([]string, end-start)
+		for i := range ids {
+			ids[i] = uuid.NewString()
+		}
+		batches = append(batches, batch{docs: docs[start:end], ids: ids})
+	}
+
+	var (
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, concurrency)
+		mu    sync.Mutex
+		errs  []error
+		added int
+	)
+
+	for _, b := range batches {
+		b := b
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := s.addBatch(ctx, c, b.docs, b.ids); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+
+				return
+			}
+
+			// The callback runs under mu so calls don't overlap and report
+			// the added count in increasing order.
+			mu.Lock()
+			defer mu.Unlock()
+			added += len(b.docs)
+
+			if s.progressCallback != nil {
+				s.progressCallback(added, len(docs))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("couldn't add %d out of %d batches: %w", len(errs), len(batches), errors.Join(errs...))
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, b := range batches {
+		ids = append(ids, b.ids...)
+	}
+
+	return ids, nil
+}
+
+// addBatch embeds and inserts a single batch. If s.embedder implements
+// [BatchEmbedder] and hints that it's fine to do so, the batch is handed
+// straight to chromem-go's own [chromemgo.Collection.AddDocuments], which
+// embeds and inserts it concurrently. Otherwise we fall back to embedding the
+// whole batch ourselves with one EmbedDocuments call and inserting documents
+// one at a time, since we don't know whether the langchaingo embedder
+// implementation that's injected behaves well when called concurrently,
+// document by document.
+func (s *Store) addBatch(ctx context.Context, c *chromemgo.Collection, docs []schema.Document, ids []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	cdocs := make([]chromemgo.Document, len(docs))
+	for i, doc := range docs {
+		metadata, err := encodeMetadataMap(doc.Metadata)
+		if err != nil {
+			return fmt.Errorf("couldn't encode metadata: %w", err)
+		}
+
+		cdocs[i] = chromemgo.Document{ID: ids[i], Metadata: metadata, Content: doc.PageContent}
+	}
+
+	if be, ok := s.embedder.(BatchEmbedder); ok && be.SupportsChromemBatching() {
+		if err := c.AddDocuments(ctx, cdocs, s.concurrency); err != nil {
+			return fmt.Errorf("couldn't add documents: %w", err)
+		}
+
+		return nil
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.PageContent
+	}
+	embeds, err := s.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("couldn't embed documents: %w", err)
+	}
+
+	for i := range cdocs {
+		cdocs[i].Embedding = embeds[i]
+
+		if err := c.AddDocument(ctx, cdocs[i]); err != nil {
+			return fmt.Errorf("couldn't add document: %w", err)
+		}
+	}
+
+	return nil
+}
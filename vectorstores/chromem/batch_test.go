@@ -0,0 +1,104 @@
+package chromem
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestStore_AddDocuments_BatchedWithProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var (
+		mu        sync.Mutex
+		lastAdded int
+		lastTotal int
+		calls     int
+	)
+
+	store, err := New(
+		WithEmbedder(fakeEmbedder{}),
+		WithDefaultNamespace("cities"),
+		WithBatchSize(2),
+		WithConcurrency(2),
+		WithProgressCallback(func(added, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			lastAdded = added
+			lastTotal = total
+		}),
+	)
+	require.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "Tokyo"},
+		{PageContent: "Kyoto"},
+		{PageContent: "Paris"},
+		{PageContent: "London"},
+		{PageContent: "Santiago"},
+	}
+
+	ids, err := store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+	require.Len(t, ids, len(docs))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 3, calls) // batches of 2, 2, 1
+	require.Equal(t, len(docs), lastTotal)
+	require.Equal(t, len(docs), lastAdded)
+
+	got, err := store.SimilaritySearch(ctx, "Tokyo", len(docs))
+	require.NoError(t, err)
+	require.Len(t, got, len(docs))
+}
+
+// batchHintEmbedder wraps fakeEmbedder and implements [BatchEmbedder],
+// counting how many times EmbedDocuments is called so tests can tell whether
+// addBatch delegated to chromem-go's own Collection.AddDocuments instead.
+type batchHintEmbedder struct {
+	fakeEmbedder
+
+	supportsBatching bool
+	embedDocsCalls   *int32
+}
+
+func (e batchHintEmbedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	atomic.AddInt32(e.embedDocsCalls, 1)
+
+	return e.fakeEmbedder.EmbedDocuments(ctx, texts)
+}
+
+func (e batchHintEmbedder) SupportsChromemBatching() bool {
+	return e.supportsBatching
+}
+
+func TestStore_AddDocuments_DelegatesToChromemWhenBatchingHinted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var calls int32
+
+	store, err := New(
+		WithEmbedder(batchHintEmbedder{supportsBatching: true, embedDocsCalls: &calls}),
+		WithDefaultNamespace("cities"),
+	)
+	require.NoError(t, err)
+
+	docs := []schema.Document{{PageContent: "Tokyo"}, {PageContent: "Kyoto"}}
+	ids, err := store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+	require.Len(t, ids, len(docs))
+	require.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+	got, err := store.SimilaritySearch(ctx, "Tokyo", len(docs))
+	require.NoError(t, err)
+	require.Len(t, got, len(docs))
+}
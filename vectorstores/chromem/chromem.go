@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 
-	"github.com/google/uuid"
 	chromemgo "github.com/philippgille/chromem-go"
 	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
@@ -19,10 +19,22 @@ type Store struct {
 	persistPath string
 	compress    bool
 
+	// Export/Import options
+	exportOnClosePath     string
+	exportOnCloseCompress bool
+	exportOnCloseKey      string
+	autoImportPath        string
+	autoImportKey         string
+
 	// Collection options
 	defaultCollectionName string // langchaingo "namespace", can be overwritten in each document addition/query
 	embedder              embeddings.Embedder
 
+	// AddDocuments batching options
+	batchSize        int
+	concurrency      int
+	progressCallback func(added, total int)
+
 	// Created based on above options
 	db *chromemgo.DB
 	ef chromemgo.EmbeddingFunc
@@ -56,14 +68,68 @@ func New(opts ...Option) (*Store, error) {
 		return s.embedder.EmbedQuery(ctx, text)
 	}
 
+	if s.autoImportPath != "" {
+		if _, err := os.Stat(s.autoImportPath); err == nil {
+			if err := s.Import(s.autoImportPath, s.autoImportKey); err != nil {
+				return nil, fmt.Errorf("couldn't auto-import DB: %w", err)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("couldn't check auto-import file: %w", err)
+		}
+	}
+
 	return s, nil
 }
 
+// Export writes the whole DB, i.e. all collections and their documents, to a
+// single file at path. If compress is true, the file is gzip compressed. If
+// encryptionKey is non-empty, the file is encrypted with it (AES-256, so
+// encryptionKey must be exactly 32 bytes long); pass an empty string to write
+// it unencrypted.
+//
+// This is an alternative to [WithPersistence], which persists one file per
+// document as they're added instead of a single snapshot, and works
+// regardless of whether the store was created with that option.
+func (s *Store) Export(path string, compress bool, encryptionKey string) error {
+	if err := s.db.ExportToFile(path, compress, encryptionKey); err != nil {
+		return fmt.Errorf("couldn't export DB: %w", err)
+	}
+
+	return nil
+}
+
+// Import reads a file previously written by [Store.Export] (or by
+// [WithExportOnClose]) and adds its collections and documents to the DB.
+// encryptionKey must match the 32-byte key the file was exported with, or be
+// an empty string if it wasn't encrypted.
+func (s *Store) Import(path string, encryptionKey string) error {
+	if err := s.db.ImportFromFile(path, encryptionKey); err != nil {
+		return fmt.Errorf("couldn't import DB: %w", err)
+	}
+
+	return nil
+}
+
+// Close exports the DB to the path configured via [WithExportOnClose], if
+// any. It's a no-op otherwise, since [Store] doesn't hold any other resource
+// that needs closing.
+func (s *Store) Close() error {
+	if s.exportOnClosePath == "" {
+		return nil
+	}
+
+	return s.Export(s.exportOnClosePath, s.exportOnCloseCompress, s.exportOnCloseKey)
+}
+
 // AddDocuments adds the documents to the chromem-go DB and returns the IDs of
 // the added documents. More precisely, the documents are added to the
 // collection that's associated to the namespace that's set as the store's
 // configured default namespace or passed via options to this method. One of the
 // two namespaces must be set. If both are set, the latter takes precedence.
+//
+// By default all documents are embedded and inserted as a single batch. Use
+// [WithBatchSize], [WithConcurrency] and [WithProgressCallback] to shard large
+// inputs into concurrent batches instead.
 func (s *Store) AddDocuments(ctx context.Context, docs []schema.Document, options ...vectorstores.Option) ([]string, error) {
 	opts := getOptions(vectorstores.Options{NameSpace: s.defaultCollectionName}, options...)
 	if err := validateOptions(opts); err != nil {
@@ -78,53 +144,7 @@ func (s *Store) AddDocuments(ctx context.Context, docs []schema.Document, option
 		return nil, fmt.Errorf("couldn't get or create collection: %w", err)
 	}
 
-	// While we might be able to benefit from chromem-go's concurrency (letting
-	// it create the embeddings in parallel) we don't know whether the
-	// langchaingo embedder implementation that's injected has some batch
-	// optimizations which might be more efficient. So we create the embeddings
-	// in advance and then add documents one by one.
-
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.PageContent)
-	}
-	embeddings, err := s.embedder.EmbedDocuments(ctx, texts)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't embed documents: %w", err)
-	}
-
-	ids := make([]string, 0, len(docs))
-	for i, doc := range docs {
-		id := uuid.NewString()
-		// So far chromem-go only supports string values in the metadata.
-		// TODO: As a temporary workaround until other types are allowed in
-		// chromem-go, we could convert from some (simple) types to string here.
-		var metadata map[string]string
-		if len(doc.Metadata) > 0 {
-			metadata = map[string]string{}
-			for k, v := range doc.Metadata {
-				vString, ok := v.(string)
-				if !ok {
-					return nil, errors.New("only string values are supported in the metadata map")
-				}
-				metadata[k] = vString
-			}
-		}
-
-		err = c.AddDocument(ctx, chromemgo.Document{
-			ID:        id,
-			Metadata:  metadata,
-			Embedding: embeddings[i],
-			Content:   doc.PageContent,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("couldn't add document: %w", err)
-		}
-
-		ids = append(ids, id)
-	}
-
-	return ids, nil
+	return s.addDocumentsInBatches(ctx, c, docs)
 }
 
 // SimilaritySearch searches for similar documents in the chromem-go DB using
@@ -133,14 +153,28 @@ func (s *Store) AddDocuments(ctx context.Context, docs []schema.Document, option
 // the store's configured default namespace or passed via options to this
 // method. One of the two namespaces must be set. If both are set, the latter
 // takes precedence.
+//
+// Pass [vectorstores.WithMMR] to re-rank results with Maximal Marginal
+// Relevance instead of plain similarity order; use [Store.SimilaritySearchMMR]
+// directly if you need control over how many candidates are fetched before
+// re-ranking.
 func (s *Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) {
 	opts := getOptions(vectorstores.Options{NameSpace: s.defaultCollectionName}, options...)
 	if err := validateOptions(opts); err != nil {
 		return nil, fmt.Errorf("invalid options: %w", err)
 	}
-	var where map[string]string
-	if opts.Filters != nil {
-		where = opts.Filters.(map[string]string)
+
+	if opts.MMR {
+		if opts.MMRLambda < 0 || opts.MMRLambda > 1 {
+			return nil, errors.New("MMR lambda must be between 0 and 1")
+		}
+
+		return s.similaritySearchMMR(ctx, opts, query, numDocuments, numDocuments*defaultMMRFetchMultiplier, float64(opts.MMRLambda))
+	}
+
+	where, whereDocument, residual, err := resolveFilter(opts.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't resolve filters: %w", err)
 	}
 
 	// Get collection
@@ -151,28 +185,31 @@ func (s *Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 
 	// chromem-go doesn't support a threshold yet, so we fetch the desired
 	// number of docs first, and filter by threshold later
-	docs, err := c.Query(ctx, query, numDocuments, where, nil)
+	docs, err := c.Query(ctx, query, numDocuments, where, whereDocument)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't query collection: %w", err)
 	}
 
-	// Filter by threshold
+	// Filter by threshold and any residual Where conditions chromem-go
+	// couldn't evaluate itself.
 	var res []schema.Document
 	for _, doc := range docs {
-		if doc.Similarity >= opts.ScoreThreshold {
-			var metadata map[string]any
-			if len(doc.Metadata) > 0 {
-				metadata = map[string]any{}
-				for k, v := range doc.Metadata {
-					metadata[k] = v
-				}
-			}
-			res = append(res, schema.Document{
-				PageContent: doc.Content,
-				Metadata:    metadata,
-				Score:       doc.Similarity,
-			})
+		if doc.Similarity < opts.ScoreThreshold {
+			continue
+		}
+		if residual != nil && !residual(doc.Metadata) {
+			continue
+		}
+
+		metadata, err := decodeMetadataMap(doc.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode metadata: %w", err)
 		}
+		res = append(res, schema.Document{
+			PageContent: doc.Content,
+			Metadata:    metadata,
+			Score:       doc.Similarity,
+		})
 	}
 
 	return res, nil
@@ -204,18 +241,41 @@ func validateOptions(opts vectorstores.Options) error {
 	if opts.ScoreThreshold < 0 || opts.ScoreThreshold > 1 {
 		return errors.New("score threshold must be between 0 and 1")
 	}
-	// chromem-go supports filters for metadata and document content. Most
-	// vector store implementations in langchaingo seem to focus on metadata
-	// filters so we focus on that first.
-	// TODO: Implement a way to make *both* types of filters usable via the
-	// filters option.
-	if opts.Filters != nil {
-		if _, ok := opts.Filters.(map[string]string); !ok {
-			return errors.New("filters must be of type map[string]string")
-		}
+	// chromem-go supports filters for metadata and document content. A plain
+	// map only covers metadata equality; [Filter] additionally covers $ne/$in
+	// and document-content filters - see [resolveFilter].
+	switch opts.Filters.(type) {
+	case nil, map[string]string, map[string]any, Filter:
+	default:
+		return errors.New("filters must be of type map[string]string, map[string]any or Filter")
 	}
 
 	return nil
 }
 
-// TODO: Export and Import
+// resolveWhere translates the filters option, which may be nil, a
+// map[string]string, or a map[string]any, into the map[string]string that
+// [chromemgo.Collection.Query] expects as its "where" argument. Both map
+// forms are encoded via [encodeMetadataMap] (converting a map[string]string
+// to map[string]any first), since that's how [Store.AddDocuments] stores
+// metadata - a plain string value is stored with the same "s:" prefix as one
+// that came in through a map[string]any. [Filter] filters go through
+// [resolveFilter] instead, since they also need the "whereDocument" argument
+// and a residual predicate.
+func resolveWhere(filters any) (map[string]string, error) {
+	switch f := filters.(type) {
+	case nil:
+		return nil, nil
+	case map[string]string:
+		md := make(map[string]any, len(f))
+		for k, v := range f {
+			md[k] = v
+		}
+
+		return encodeMetadataMap(md)
+	case map[string]any:
+		return encodeMetadataMap(f)
+	default:
+		return nil, fmt.Errorf("unsupported filters type %T", filters)
+	}
+}
@@ -0,0 +1,112 @@
+package chromem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// testEncryptionKey is a valid AES-256 key for Export/Import tests: chromem-go
+// requires it to be exactly 32 bytes long, or empty to skip encryption.
+const testEncryptionKey = "01234567890123456789012345678901"
+
+// fakeEmbedder is a deterministic, dependency-free stand-in for a real
+// embedder, so these tests don't need network access or API keys.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vecs := make([][]float32, len(texts))
+	for i, text := range texts {
+		vecs[i] = fakeEmbed(text)
+	}
+
+	return vecs, nil
+}
+
+func (fakeEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return fakeEmbed(text), nil
+}
+
+// fakeEmbed turns text into a short vector derived from its bytes, so
+// identical text always embeds identically and SimilaritySearch has
+// something meaningful to compare.
+func fakeEmbed(text string) []float32 {
+	v := make([]float32, 8)
+	for i, r := range text {
+		v[i%len(v)] += float32(r)
+	}
+
+	return v
+}
+
+func TestStore_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	docs := []schema.Document{
+		{PageContent: "Tokyo"},
+		{PageContent: "Paris"},
+		{PageContent: "Santiago"},
+	}
+
+	store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+
+	_, err = store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+
+	want, err := store.SimilaritySearch(ctx, "Tokyo", 3)
+	require.NoError(t, err)
+
+	exportPath := filepath.Join(t.TempDir(), "chromem.db.gz")
+	require.NoError(t, store.Export(exportPath, true, testEncryptionKey))
+
+	imported, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+	require.NoError(t, imported.Import(exportPath, testEncryptionKey))
+
+	got, err := imported.SimilaritySearch(ctx, "Tokyo", 3)
+	require.NoError(t, err)
+
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.Equal(t, want[i].PageContent, got[i].PageContent)
+	}
+}
+
+func TestStore_AutoImportAndExportOnClose(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot.db")
+
+	store, err := New(
+		WithEmbedder(fakeEmbedder{}),
+		WithDefaultNamespace("cities"),
+		WithExportOnClose(snapshotPath, false, ""),
+	)
+	require.NoError(t, err)
+
+	_, err = store.AddDocuments(ctx, []schema.Document{{PageContent: "Kyoto"}})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	_, err = os.Stat(snapshotPath)
+	require.NoError(t, err)
+
+	reopened, err := New(
+		WithEmbedder(fakeEmbedder{}),
+		WithDefaultNamespace("cities"),
+		WithAutoImport(snapshotPath, ""),
+	)
+	require.NoError(t, err)
+
+	got, err := reopened.SimilaritySearch(ctx, "Kyoto", 1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "Kyoto", got[0].PageContent)
+}
@@ -0,0 +1,166 @@
+package chromem
+
+import "fmt"
+
+// Op describes a metadata comparison for a single field in [Filter.Where].
+// Set exactly one field; the zero value matches everything.
+type Op struct {
+	Eq any   // field equals Eq
+	Ne any   // field doesn't equal Ne
+	In []any // field equals one of In
+}
+
+// ContentFilter restricts results by the document content itself, as opposed
+// to its metadata. It maps to chromem-go's "whereDocument" query argument.
+type ContentFilter struct {
+	Contains    string
+	NotContains string
+}
+
+// Filter is the structured filter type accepted via [vectorstores.WithFilters]
+// for this store. Unlike a plain map[string]string or map[string]any, it can
+// express both of chromem-go's filter mechanisms at once - metadata filters
+// (Metadata, Where) and document-content filters (Content).
+//
+// Metadata is a shorthand for equality matches. Where additionally supports
+// $ne and $in, at the cost of being evaluated client-side after the query,
+// since chromem-go's own "where" argument only supports equality.
+type Filter struct {
+	Metadata map[string]any
+	Where    map[string]Op
+	Content  *ContentFilter
+}
+
+// resolveFilter translates filters - nil, a map[string]string, a
+// map[string]any, or a [Filter] - into the (where, whereDocument) arguments
+// that [chromemgo.Collection.Query] accepts, plus a residual predicate for
+// the parts of a [Filter] that chromem-go can't evaluate itself (Where's $ne
+// and $in). residual is nil if every condition could be pushed down into
+// where/whereDocument.
+func resolveFilter(filters any) (where, whereDocument map[string]string, residual func(map[string]string) bool, err error) {
+	f, ok := filters.(Filter)
+	if !ok {
+		where, err = resolveWhere(filters)
+		return where, nil, nil, err
+	}
+
+	where, err = encodeMetadataMap(f.Metadata)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("couldn't encode filter metadata: %w", err)
+	}
+
+	var residualOps map[string]encodedOp
+	for field, op := range f.Where {
+		if op.Eq != nil {
+			encoded, err := encodeMetadataValue(op.Eq)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("couldn't encode filter value for %q: %w", field, err)
+			}
+			if where == nil {
+				where = map[string]string{}
+			}
+			where[field] = encoded
+
+			continue
+		}
+		if op.Ne != nil {
+			encoded, err := encodeMetadataValue(op.Ne)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("couldn't encode filter value for %q: %w", field, err)
+			}
+			if residualOps == nil {
+				residualOps = map[string]encodedOp{}
+			}
+			eop := residualOps[field]
+			eop.ne = &encoded
+			residualOps[field] = eop
+		}
+		if op.In != nil {
+			encoded := make([]string, len(op.In))
+			for i, v := range op.In {
+				enc, err := encodeMetadataValue(v)
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("couldn't encode filter value for %q: %w", field, err)
+				}
+				encoded[i] = enc
+			}
+			if residualOps == nil {
+				residualOps = map[string]encodedOp{}
+			}
+			eop := residualOps[field]
+			eop.in = encoded
+			residualOps[field] = eop
+		}
+	}
+
+	if f.Content != nil {
+		if f.Content.Contains != "" || f.Content.NotContains != "" {
+			whereDocument = map[string]string{}
+			if f.Content.Contains != "" {
+				whereDocument["$contains"] = f.Content.Contains
+			}
+			if f.Content.NotContains != "" {
+				whereDocument["$not_contains"] = f.Content.NotContains
+			}
+		}
+	}
+
+	if len(residualOps) > 0 {
+		residual = makeResidualFilter(residualOps)
+	}
+
+	return where, whereDocument, residual, nil
+}
+
+// encodedOp holds the already-[encodeMetadataValue]-encoded form of a $ne
+// and/or $in operand for a single field, so [makeResidualFilter] can compare
+// a document's raw chromem-go metadata string against it directly. Comparing
+// the encoded forms, rather than stringifying the decoded Go values, keeps
+// the comparison type-aware: encoding carries a type prefix, so e.g. the bool
+// "b:true" and the string "s:true" never compare equal even though both
+// would stringify to "true".
+type encodedOp struct {
+	ne *string
+	in []string
+}
+
+// makeResidualFilter builds a predicate over a document's raw chromem-go
+// metadata for the Where operators that couldn't be pushed down into the
+// query itself.
+func makeResidualFilter(ops map[string]encodedOp) func(map[string]string) bool {
+	return func(metadata map[string]string) bool {
+		for field, op := range ops {
+			raw, ok := metadata[field]
+			if !ok {
+				// A missing field trivially satisfies $ne (it's not equal to
+				// anything), the same way Mongo-style $ne treats an absent
+				// field, but can't satisfy $in, which needs the field to
+				// equal one of a set of values.
+				if op.in != nil {
+					return false
+				}
+
+				continue
+			}
+
+			if op.ne != nil && raw == *op.ne {
+				return false
+			}
+			if op.in != nil && !containsAny(op.in, raw) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+func containsAny(candidates []string, raw string) bool {
+	for _, candidate := range candidates {
+		if candidate == raw {
+			return true
+		}
+	}
+
+	return false
+}
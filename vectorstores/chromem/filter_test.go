@@ -0,0 +1,112 @@
+package chromem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+func TestStore_FilterStruct(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "Sao Paulo is the biggest city in Brazil", Metadata: map[string]any{"area": 1523}},
+		{PageContent: "Rio de Janeiro is famous for its beaches", Metadata: map[string]any{"area": 1200}},
+		{PageContent: "Santiago is the capital of Chile", Metadata: map[string]any{"area": 641}},
+	}
+	_, err = store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+
+	t.Run("Where $ne", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := store.SimilaritySearch(ctx, "city", len(docs), vectorstores.WithFilters(Filter{
+			Where: map[string]Op{"area": {Ne: 1523}},
+		}))
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		for _, doc := range got {
+			require.NotEqual(t, "Sao Paulo is the biggest city in Brazil", doc.PageContent)
+		}
+	})
+
+	t.Run("Where $in", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := store.SimilaritySearch(ctx, "city", len(docs), vectorstores.WithFilters(Filter{
+			Where: map[string]Op{"area": {In: []any{1523, 641}}},
+		}))
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+	})
+
+	t.Run("Content filter", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := store.SimilaritySearch(ctx, "city", len(docs), vectorstores.WithFilters(Filter{
+			Content: &ContentFilter{Contains: "beaches"},
+		}))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "Rio de Janeiro is famous for its beaches", got[0].PageContent)
+	})
+
+	t.Run("Where $ne doesn't conflate types that stringify the same", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("verified"))
+		require.NoError(t, err)
+
+		_, err = store.AddDocuments(ctx, []schema.Document{
+			{PageContent: "bool true", Metadata: map[string]any{"active": true}},
+			{PageContent: "string true", Metadata: map[string]any{"active": "true"}},
+		})
+		require.NoError(t, err)
+
+		got, err := store.SimilaritySearch(ctx, "true", 2, vectorstores.WithFilters(Filter{
+			Where: map[string]Op{"active": {Ne: "true"}},
+		}))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "bool true", got[0].PageContent)
+	})
+
+	t.Run("Where $ne matches documents missing the field", func(t *testing.T) {
+		t.Parallel()
+
+		store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("unverified"))
+		require.NoError(t, err)
+
+		_, err = store.AddDocuments(ctx, []schema.Document{
+			{PageContent: "has verified=true", Metadata: map[string]any{"verified": true}},
+			{PageContent: "has no verified field"},
+		})
+		require.NoError(t, err)
+
+		got, err := store.SimilaritySearch(ctx, "verified", 2, vectorstores.WithFilters(Filter{
+			Where: map[string]Op{"verified": {Ne: true}},
+		}))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "has no verified field", got[0].PageContent)
+	})
+
+	t.Run("Metadata and Where combined", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := store.SimilaritySearch(ctx, "city", len(docs), vectorstores.WithFilters(Filter{
+			Metadata: map[string]any{"area": 641},
+			Where:    map[string]Op{"area": {Ne: 1523}},
+		}))
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "Santiago is the capital of Chile", got[0].PageContent)
+	})
+}
@@ -0,0 +1,235 @@
+package chromem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// chromem-go only stores metadata as map[string]string, so richer Go types
+// are encoded into a short, typed string representation on write and decoded
+// back on read. A value with no recognized prefix is treated as a plain
+// string, so metadata written before this codec existed keeps working.
+//
+// Numbers carry a prefix for whether they're integral (metadataPrefixInt) or
+// not (metadataPrefixNumber), so e.g. an int round-trips as an int64 rather
+// than always coming back as float64. Go's specific integer/float widths
+// (int32 vs int64, float32 vs float64) aren't preserved, only that split.
+//
+// Slices carry their own prefix per element type (e.g. metadataPrefixSliceString
+// for []string) so they decode back to that concrete slice type rather than a
+// generic []any. Only []any itself decodes to []any, since its elements
+// aren't guaranteed to share a type. Within metadataPrefixSliceNumber, integers
+// and floats aren't distinguished and always decode as float64 - unlike the
+// scalar case, since a slice's element type is fixed by its Go type and can't
+// carry a per-element int/float distinction without changing that type.
+const (
+	metadataPrefixString = "s:"
+	metadataPrefixInt    = "i:"
+	metadataPrefixNumber = "n:"
+	metadataPrefixBool   = "b:"
+	metadataPrefixTime   = "t:"
+
+	metadataPrefixSliceString = "as:"
+	metadataPrefixSliceNumber = "an:"
+	metadataPrefixSliceBool   = "ab:"
+	metadataPrefixSliceTime   = "at:"
+	metadataPrefixSliceAny    = "aa:"
+)
+
+// metadataSliceSep separates encoded slice elements. It's a control character
+// that's exceedingly unlikely to show up in real metadata values.
+const metadataSliceSep = "\x1f"
+
+// encodeMetadataValue encodes v into chromem-go's map[string]string metadata
+// representation. Supported types are string, bool, int, int32, int64,
+// float32, float64, time.Time, and the slices []string, []bool, []int,
+// []int64, []float64, []time.Time and []any (for mixed slices). Any other
+// type, e.g. uint or []float32, returns an error.
+func encodeMetadataValue(v any) (string, error) {
+	switch val := v.(type) {
+	case string:
+		return metadataPrefixString + val, nil
+	case bool:
+		return metadataPrefixBool + strconv.FormatBool(val), nil
+	case int:
+		return metadataPrefixInt + strconv.Itoa(val), nil
+	case int32:
+		return metadataPrefixInt + strconv.FormatInt(int64(val), 10), nil
+	case int64:
+		return metadataPrefixInt + strconv.FormatInt(val, 10), nil
+	case float32:
+		return metadataPrefixNumber + strconv.FormatFloat(float64(val), 'g', -1, 32), nil
+	case float64:
+		return metadataPrefixNumber + strconv.FormatFloat(val, 'g', -1, 64), nil
+	case time.Time:
+		return metadataPrefixTime + val.Format(time.RFC3339Nano), nil
+	case []string:
+		return encodeMetadataSlice(metadataPrefixSliceString, toAnySlice(val))
+	case []bool:
+		return encodeMetadataSlice(metadataPrefixSliceBool, toAnySlice(val))
+	case []int:
+		return encodeMetadataSlice(metadataPrefixSliceNumber, toAnySlice(val))
+	case []int64:
+		return encodeMetadataSlice(metadataPrefixSliceNumber, toAnySlice(val))
+	case []float64:
+		return encodeMetadataSlice(metadataPrefixSliceNumber, toAnySlice(val))
+	case []time.Time:
+		return encodeMetadataSlice(metadataPrefixSliceTime, toAnySlice(val))
+	case []any:
+		return encodeMetadataSlice(metadataPrefixSliceAny, val)
+	default:
+		return "", fmt.Errorf("unsupported metadata value type %T", v)
+	}
+}
+
+// toAnySlice converts a typed slice to []any so its elements can go through
+// the same per-element encoding as encodeMetadataValue.
+func toAnySlice[T any](s []T) []any {
+	out := make([]any, len(s))
+	for i, v := range s {
+		out[i] = v
+	}
+
+	return out
+}
+
+func encodeMetadataSlice(prefix string, items []any) (string, error) {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		encoded, err := encodeMetadataValue(item)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = encoded
+	}
+
+	return prefix + strings.Join(parts, metadataSliceSep), nil
+}
+
+// decodeMetadataValue is the inverse of encodeMetadataValue.
+func decodeMetadataValue(s string) (any, error) {
+	switch {
+	case strings.HasPrefix(s, metadataPrefixSliceString):
+		items, err := decodeMetadataSlice(strings.TrimPrefix(s, metadataPrefixSliceString))
+		if err != nil {
+			return nil, err
+		}
+
+		return toTypedSlice[string](items)
+	case strings.HasPrefix(s, metadataPrefixSliceBool):
+		items, err := decodeMetadataSlice(strings.TrimPrefix(s, metadataPrefixSliceBool))
+		if err != nil {
+			return nil, err
+		}
+
+		return toTypedSlice[bool](items)
+	case strings.HasPrefix(s, metadataPrefixSliceNumber):
+		items, err := decodeMetadataSlice(strings.TrimPrefix(s, metadataPrefixSliceNumber))
+		if err != nil {
+			return nil, err
+		}
+		// Integer elements are encoded with metadataPrefixInt and so decode
+		// as int64; convert them to match the float64 element type.
+		for i, item := range items {
+			if n, ok := item.(int64); ok {
+				items[i] = float64(n)
+			}
+		}
+
+		return toTypedSlice[float64](items)
+	case strings.HasPrefix(s, metadataPrefixSliceTime):
+		items, err := decodeMetadataSlice(strings.TrimPrefix(s, metadataPrefixSliceTime))
+		if err != nil {
+			return nil, err
+		}
+
+		return toTypedSlice[time.Time](items)
+	case strings.HasPrefix(s, metadataPrefixSliceAny):
+		return decodeMetadataSlice(strings.TrimPrefix(s, metadataPrefixSliceAny))
+	case strings.HasPrefix(s, metadataPrefixString):
+		return strings.TrimPrefix(s, metadataPrefixString), nil
+	case strings.HasPrefix(s, metadataPrefixBool):
+		return strconv.ParseBool(strings.TrimPrefix(s, metadataPrefixBool))
+	case strings.HasPrefix(s, metadataPrefixInt):
+		return strconv.ParseInt(strings.TrimPrefix(s, metadataPrefixInt), 10, 64)
+	case strings.HasPrefix(s, metadataPrefixNumber):
+		return strconv.ParseFloat(strings.TrimPrefix(s, metadataPrefixNumber), 64)
+	case strings.HasPrefix(s, metadataPrefixTime):
+		return time.Parse(time.RFC3339Nano, strings.TrimPrefix(s, metadataPrefixTime))
+	default:
+		// Unprefixed values predate this codec; treat them as plain strings.
+		return s, nil
+	}
+}
+
+func decodeMetadataSlice(raw string) ([]any, error) {
+	if raw == "" {
+		return []any{}, nil
+	}
+
+	parts := strings.Split(raw, metadataSliceSep)
+	items := make([]any, len(parts))
+	for i, part := range parts {
+		item, err := decodeMetadataValue(part)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+
+	return items, nil
+}
+
+// toTypedSlice asserts every element of items to T, so a slice that was
+// encoded with a single-type prefix (e.g. metadataPrefixSliceString) decodes
+// back to that concrete slice type instead of []any.
+func toTypedSlice[T any](items []any) ([]T, error) {
+	out := make([]T, len(items))
+	for i, item := range items {
+		v, ok := item.(T)
+		if !ok {
+			return nil, fmt.Errorf("couldn't decode slice element %d as %T", i, *new(T))
+		}
+		out[i] = v
+	}
+
+	return out, nil
+}
+
+// encodeMetadataMap encodes every value of md using encodeMetadataValue.
+func encodeMetadataMap(md map[string]any) (map[string]string, error) {
+	if len(md) == 0 {
+		return nil, nil
+	}
+
+	encoded := make(map[string]string, len(md))
+	for k, v := range md {
+		s, err := encodeMetadataValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't encode metadata key %q: %w", k, err)
+		}
+		encoded[k] = s
+	}
+
+	return encoded, nil
+}
+
+// decodeMetadataMap decodes every value of md using decodeMetadataValue.
+func decodeMetadataMap(md map[string]string) (map[string]any, error) {
+	if len(md) == 0 {
+		return nil, nil
+	}
+
+	decoded := make(map[string]any, len(md))
+	for k, v := range md {
+		val, err := decodeMetadataValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode metadata key %q: %w", k, err)
+		}
+		decoded[k] = val
+	}
+
+	return decoded, nil
+}
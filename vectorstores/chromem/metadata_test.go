@@ -0,0 +1,103 @@
+package chromem
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+func TestEncodeDecodeMetadataValue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	// want is the input to encodeMetadataValue; wantDecoded is what
+	// decodeMetadataValue must return for it. Integers round-trip as int64
+	// and floats as float64 - Go's specific widths (int32 vs int64, float32
+	// vs float64) aren't preserved, only the int/float distinction. Every
+	// other type, including slices, round-trips to its original concrete Go
+	// type; within a numeric slice, though, elements always decode as
+	// float64 since the slice's element type can't carry a per-element
+	// int/float distinction.
+	cases := []struct {
+		name        string
+		want        any
+		wantDecoded any
+	}{
+		{"string", "foo", "foo"},
+		{"bool true", true, true},
+		{"bool false", false, false},
+		{"int", 1523, int64(1523)},
+		{"float64", 1.5, 1.5},
+		{"time.Time", now, now},
+		{"[]string", []string{"a", "b"}, []string{"a", "b"}},
+		{"[]int", []int{1, 2, 3}, []float64{1, 2, 3}},
+		{"[]bool", []bool{true, false}, []bool{true, false}},
+		{"[]time.Time", []time.Time{now}, []time.Time{now}},
+		{"[]any", []any{"a", 1.0, true}, []any{"a", 1.0, true}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := encodeMetadataValue(c.want)
+			require.NoError(t, err)
+
+			got, err := decodeMetadataValue(encoded)
+			require.NoError(t, err)
+			require.Equal(t, c.wantDecoded, got)
+		})
+	}
+}
+
+func TestStore_RichMetadataFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "Sao Paulo", Metadata: map[string]any{"area": 1523, "verified": true}},
+		{PageContent: "Paris", Metadata: map[string]any{"area": 105, "verified": false}},
+	}
+	_, err = store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+
+	got, err := store.SimilaritySearch(ctx, "city", len(docs),
+		vectorstores.WithFilters(map[string]any{"area": 1523}),
+	)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "Sao Paulo", got[0].PageContent)
+	require.InDelta(t, 1523.0, got[0].Metadata["area"], 0)
+	require.Equal(t, true, got[0].Metadata["verified"])
+}
+
+func TestStore_StringMetadataFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "Sao Paulo", Metadata: map[string]any{"country": "Brazil"}},
+		{PageContent: "Paris", Metadata: map[string]any{"country": "France"}},
+	}
+	_, err = store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+
+	// map[string]string is matched against the same encoded representation
+	// that AddDocuments stores metadata as, not the raw string.
+	got, err := store.SimilaritySearch(ctx, "city", len(docs),
+		vectorstores.WithFilters(map[string]string{"country": "Brazil"}),
+	)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "Sao Paulo", got[0].PageContent)
+}
@@ -0,0 +1,181 @@
+package chromem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	chromemgo "github.com/philippgille/chromem-go"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// defaultMMRFetchMultiplier is multiplied by k to get the fetchK used for [vectorstores.WithMMR] via
+// [Store.SimilaritySearch], which - unlike [Store.SimilaritySearchMMR] - has
+// no way to take fetchK as an explicit argument. It's deliberately generous
+// since a small fetch pool defeats the point of re-ranking for diversity.
+const defaultMMRFetchMultiplier = 4
+
+// SimilaritySearchMMR searches for documents like [Store.SimilaritySearch],
+// but re-ranks the fetchK closest candidates with Maximal Marginal Relevance
+// before returning the k most relevant *and* diverse ones. This trades a bit
+// of raw relevance for fewer near-duplicate chunks, which tends to pay off
+// when the results feed an LLM's limited context window.
+//
+// lambda controls the relevance/diversity trade-off: 1 behaves like a plain
+// similarity search, 0 maximizes diversity. fetchK must be at least k; a
+// common choice is fetchK = 3*k or more.
+//
+// Use [vectorstores.WithMMR] instead if you want MMR re-ranking through
+// [Store.SimilaritySearch] itself, e.g. because you build up a generic
+// []vectorstores.Option shared across backends; it defaults fetchK for you
+// since the shared vectorstores.Options has no room for it.
+func (s *Store) SimilaritySearchMMR(ctx context.Context, query string, k, fetchK int, lambda float64, options ...vectorstores.Option) ([]schema.Document, error) {
+	opts := getOptions(vectorstores.Options{NameSpace: s.defaultCollectionName}, options...)
+	if err := validateOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+	if k <= 0 {
+		return nil, errors.New("k must be greater than 0")
+	}
+	if fetchK < k {
+		return nil, errors.New("fetchK must be greater than or equal to k")
+	}
+	if lambda < 0 || lambda > 1 {
+		return nil, errors.New("lambda must be between 0 and 1")
+	}
+
+	return s.similaritySearchMMR(ctx, opts, query, k, fetchK, lambda)
+}
+
+// similaritySearchMMR is the shared implementation behind
+// [Store.SimilaritySearchMMR] and [Store.SimilaritySearch]'s handling of
+// [vectorstores.WithMMR]. Callers are responsible for validating k, fetchK,
+// and lambda first.
+func (s *Store) similaritySearchMMR(ctx context.Context, opts vectorstores.Options, query string, k, fetchK int, lambda float64) ([]schema.Document, error) {
+	where, whereDocument, residual, err := resolveFilter(opts.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't resolve filters: %w", err)
+	}
+
+	c := s.db.GetCollection(opts.NameSpace, s.ef)
+	if c == nil {
+		return nil, errors.New("namespace doesn't exist - create it by adding documents to it first")
+	}
+	// chromem-go rejects a query for more results than the collection holds.
+	if n := c.Count(); fetchK > n {
+		fetchK = n
+	}
+
+	queryEmbedding, err := s.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't embed query: %w", err)
+	}
+
+	candidates, err := c.Query(ctx, query, fetchK, where, whereDocument)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't query collection: %w", err)
+	}
+
+	selected := selectMMR(queryEmbedding, candidates, k, lambda)
+
+	res := make([]schema.Document, 0, len(selected))
+	for _, doc := range selected {
+		if doc.Similarity < opts.ScoreThreshold {
+			continue
+		}
+		if residual != nil && !residual(doc.Metadata) {
+			continue
+		}
+		metadata, err := decodeMetadataMap(doc.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode metadata: %w", err)
+		}
+		res = append(res, schema.Document{
+			PageContent: doc.Content,
+			Metadata:    metadata,
+			Score:       doc.Similarity,
+		})
+	}
+
+	return res, nil
+}
+
+// mmrTieEpsilon is the tolerance for treating two MMR scores as tied. Ties
+// are common in practice: once a document equal (or near-identical) to the
+// query itself has been selected, every remaining candidate's relevance and
+// redundancy become the same value, so lambda*rel - (1-lambda)*red collapses
+// to the same score for all of them.
+const mmrTieEpsilon = 1e-9
+
+// selectMMR iteratively picks up to k documents from candidates, at each step
+// maximizing lambda*sim(doc, query) - (1-lambda)*max(sim(doc, selected)). On a
+// tied score, it prefers the candidate least similar to what's already
+// selected, so ties don't default to picking whichever near-duplicate
+// happens to come first in candidates.
+func selectMMR(queryEmbedding []float32, candidates []chromemgo.Result, k int, lambda float64) []chromemgo.Result {
+	if len(candidates) <= k {
+		return candidates
+	}
+
+	selected := make([]chromemgo.Result, 0, k)
+	chosen := make(map[int]bool, k)
+
+	for len(selected) < k {
+		bestIdx := -1
+		bestScore := math.Inf(-1)
+		bestRedundancy := math.Inf(1)
+
+		for i, candidate := range candidates {
+			if chosen[i] {
+				continue
+			}
+
+			relevance := cosineSimilarity(queryEmbedding, candidate.Embedding)
+
+			redundancy := 0.0
+			for _, s := range selected {
+				if sim := cosineSimilarity(s.Embedding, candidate.Embedding); sim > redundancy {
+					redundancy = sim
+				}
+			}
+
+			score := lambda*relevance - (1-lambda)*redundancy
+
+			switch {
+			case score > bestScore+mmrTieEpsilon:
+				bestIdx, bestScore, bestRedundancy = i, score, redundancy
+			case score > bestScore-mmrTieEpsilon && redundancy < bestRedundancy:
+				// Tied on score: prefer the less redundant candidate instead
+				// of whichever one happened to come first.
+				bestIdx, bestScore, bestRedundancy = i, score, redundancy
+			}
+		}
+
+		if bestIdx == -1 {
+			break
+		}
+
+		chosen[bestIdx] = true
+		selected = append(selected, candidates[bestIdx])
+	}
+
+	return selected
+}
+
+// cosineSimilarity is the same metric chromem-go uses internally for
+// SimilaritySearch, so MMR re-ranks on a comparable scale.
+func cosineSimilarity(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
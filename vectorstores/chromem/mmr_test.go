@@ -0,0 +1,79 @@
+package chromem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+func TestStore_SimilaritySearchMMR(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "Tokyo"},
+		{PageContent: "Tokyo"}, // near-duplicate, same embedding as the first
+		{PageContent: "Paris"},
+		{PageContent: "Santiago"},
+	}
+	_, err = store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+
+	got, err := store.SimilaritySearchMMR(ctx, "Tokyo", 2, 4, 0.5)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	// With a near-duplicate in the fetched set, MMR should favor diversity
+	// over returning both copies of the same content.
+	contents := map[string]bool{got[0].PageContent: true, got[1].PageContent: true}
+	require.Len(t, contents, 2)
+}
+
+func TestStore_SimilaritySearch_WithMMR(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+
+	docs := []schema.Document{
+		{PageContent: "Tokyo"},
+		{PageContent: "Tokyo"}, // near-duplicate, same embedding as the first
+		{PageContent: "Paris"},
+		{PageContent: "Santiago"},
+	}
+	_, err = store.AddDocuments(ctx, docs)
+	require.NoError(t, err)
+
+	got, err := store.SimilaritySearch(ctx, "Tokyo", 2, vectorstores.WithMMR(0.5))
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	// Same near-duplicate scenario as TestStore_SimilaritySearchMMR: MMR
+	// should favor diversity over returning both copies of the same content.
+	contents := map[string]bool{got[0].PageContent: true, got[1].PageContent: true}
+	require.Len(t, contents, 2)
+}
+
+func TestStore_SimilaritySearchMMR_InvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := New(WithEmbedder(fakeEmbedder{}), WithDefaultNamespace("cities"))
+	require.NoError(t, err)
+
+	_, err = store.SimilaritySearchMMR(ctx, "Tokyo", 0, 4, 0.5)
+	require.Error(t, err)
+
+	_, err = store.SimilaritySearchMMR(ctx, "Tokyo", 4, 2, 0.5)
+	require.Error(t, err)
+
+	_, err = store.SimilaritySearchMMR(ctx, "Tokyo", 2, 4, 1.5)
+	require.Error(t, err)
+}
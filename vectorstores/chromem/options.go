@@ -9,6 +9,7 @@ import (
 var (
 	defaultPersistPath = "./langchaingo"
 	defaultCompress    = false
+	defaultConcurrency = 1
 )
 
 // Option is a function type that can be used to modify the client.
@@ -53,11 +54,74 @@ func WithEmbedder(embedder embeddings.Embedder) Option {
 	}
 }
 
+// WithExportOnClose makes [Store.Close] export the whole DB to a single file
+// at path, so even a non-persistent store (the default) can be snapshotted.
+// If compress is true, the file is gzip compressed. If encryptionKey is
+// non-empty, the file is encrypted with it (AES-256, so encryptionKey must be
+// exactly 32 bytes long).
+//
+// See [Store.Export] for details.
+func WithExportOnClose(path string, compress bool, encryptionKey string) Option {
+	return func(s *Store) {
+		s.exportOnClosePath = path
+		s.exportOnCloseCompress = compress
+		s.exportOnCloseKey = encryptionKey
+	}
+}
+
+// WithAutoImport makes [New] import the file at path, if it exists, right
+// after creating the DB. It's the read-side counterpart to
+// [WithExportOnClose] and lets a non-persistent store resume from a prior
+// export. encryptionKey must match the 32-byte key the file was exported
+// with, or be an empty string if it wasn't encrypted.
+//
+// See [Store.Import] for details.
+func WithAutoImport(path string, encryptionKey string) Option {
+	return func(s *Store) {
+		s.autoImportPath = path
+		s.autoImportKey = encryptionKey
+	}
+}
+
+// WithBatchSize makes [Store.AddDocuments] shard its input into batches of at
+// most n documents, each embedded and inserted as a unit. Combined with
+// [WithConcurrency], this lets large corpora start producing progress (see
+// [WithProgressCallback]) instead of blocking until every document is
+// embedded. The default is to embed and insert all documents in a single
+// batch.
+func WithBatchSize(n int) Option {
+	return func(s *Store) {
+		s.batchSize = n
+	}
+}
+
+// WithConcurrency sets how many batches [Store.AddDocuments] embeds and
+// inserts in parallel. The default is 1, i.e. batches are processed
+// sequentially. Without [WithBatchSize] all documents form a single batch, so
+// the only effect left is on the hinted [BatchEmbedder] path, where n is also
+// passed as the concurrency of chromem-go's own Collection.AddDocuments.
+func WithConcurrency(n int) Option {
+	return func(s *Store) {
+		s.concurrency = n
+	}
+}
+
+// WithProgressCallback registers a function that [Store.AddDocuments] calls
+// after each batch completes, with the number of documents added so far and
+// the total being added. It's called from whichever goroutine finished that
+// batch, but calls never overlap and report an increasing added count.
+func WithProgressCallback(f func(added, total int)) Option {
+	return func(s *Store) {
+		s.progressCallback = f
+	}
+}
+
 func applyClientOptions(opts ...Option) (*Store, error) {
 	// Initialize with defaults
 	s := &Store{
 		persistPath: defaultPersistPath,
 		compress:    defaultCompress,
+		concurrency: defaultConcurrency,
 	}
 
 	for _, opt := range opts {